@@ -0,0 +1,261 @@
+/* Copyright © 2019 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
+	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
+)
+
+func dataSourceNsxtPolicyService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtPolicyServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"id":           getDataSourceIDSchema(),
+			"display_name": getDataSourceDisplayNameSchema(),
+			"description":  getDataSourceDescriptionSchema(),
+			"path":         getPathSchema(),
+
+			"icmp_entry": {
+				Type:        schema.TypeList,
+				Description: "ICMP type service entry",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"protocol": {
+							Type:        schema.TypeString,
+							Description: "Version of ICMP protocol (ICMPv4/ICMPv6)",
+							Computed:    true,
+						},
+						"icmp_type": {
+							Type:        schema.TypeString,
+							Description: "ICMP message type",
+							Computed:    true,
+						},
+						"icmp_code": {
+							Type:        schema.TypeString,
+							Description: "ICMP message code",
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"l4_port_set_entry": {
+				Type:        schema.TypeList,
+				Description: "L4 port set type service entry",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"destination_ports": {
+							Type:        schema.TypeSet,
+							Description: "Set of destination ports",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+						},
+						"source_ports": {
+							Type:        schema.TypeSet,
+							Description: "Set of source ports",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Description: "L4 Protocol",
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"igmp_entry": {
+				Type:        schema.TypeList,
+				Description: "IGMP type service entry",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+					},
+				},
+			},
+
+			"ether_type_entry": {
+				Type:        schema.TypeList,
+				Description: "Ether type service entry",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"ether_type": {
+							Type:        schema.TypeInt,
+							Description: "Type of the encapsulated protocol",
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"ip_protocol_entry": {
+				Type:        schema.TypeList,
+				Description: "IP Protocol type service entry",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"protocol": {
+							Type:        schema.TypeInt,
+							Description: "IP protocol number",
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"algorithm_entry": {
+				Type:        schema.TypeList,
+				Description: "Algorithm type service entry",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"destination_port": {
+							Type:        schema.TypeString,
+							Description: "A single destination port",
+							Computed:    true,
+						},
+						"source_ports": {
+							Type:        schema.TypeSet,
+							Description: "Set of source ports or ranges",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+						},
+						"algorithm": {
+							Type:        schema.TypeString,
+							Description: "Algorithm",
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"nested_service_entry": {
+				Type:        schema.TypeList,
+				Description: "Nested service type service entry, referencing another policy service",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id": {
+							Type:        schema.TypeString,
+							Description: "NSX ID of this service entry",
+							Computed:    true,
+						},
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"service_path": {
+							Type:        schema.TypeString,
+							Description: "The path of the nested service this entry points to",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNsxtPolicyServiceRead(d *schema.ResourceData, m interface{}) error {
+	connector := getPolicyConnector(m)
+	client := infra.NewDefaultServicesClient(connector)
+
+	objID := d.Get("id").(string)
+	displayName := d.Get("display_name").(string)
+	var obj model.Service
+	if objID != "" {
+		// Get by id
+		objGet, err := client.Get(objID)
+		if err != nil {
+			return handleDataSourceReadError(d, "Service", objID, err)
+		}
+		obj = objGet
+	} else if displayName != "" {
+		// The services List API has no display_name filter, so every page
+		// has to be scanned; this also covers NSX default services (e.g.
+		// HTTP, DNS, ICMP Echo) that are not managed by a
+		// nsxt_policy_service resource.
+		var cursor *string
+		found := false
+		for {
+			objList, err := client.List(cursor, nil, nil, nil, nil, nil)
+			if err != nil {
+				return fmt.Errorf("Error while reading services: %v", err)
+			}
+			for _, objInList := range objList.Results {
+				if objInList.DisplayName != nil && *objInList.DisplayName == displayName {
+					if found {
+						return fmt.Errorf("Found multiple services with name '%s'", displayName)
+					}
+					obj = objInList
+					found = true
+				}
+			}
+			if objList.Cursor == nil || *objList.Cursor == "" {
+				break
+			}
+			cursor = objList.Cursor
+		}
+		if !found {
+			return fmt.Errorf("Service with name '%s' was not found", displayName)
+		}
+	} else {
+		return fmt.Errorf("Error obtaining Service ID or name during read")
+	}
+
+	d.SetId(*obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	d.Set("path", obj.Path)
+
+	return setPolicyServiceEntriesInSchema(d, obj.ServiceEntries)
+}
@@ -5,6 +5,7 @@ package nsxt
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/vmware/vsphere-automation-sdk-go/runtime/bindings"
@@ -13,9 +14,25 @@ import (
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/infra"
 	"github.com/vmware/vsphere-automation-sdk-go/services/nsxt/model"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 )
 
+// getServiceEntryIDSchema returns the schema for the computed, stable id of a
+// service entry, a UUID generated when the entry is first sent to NSX. It is
+// kept across updates (see
+// resourceNsxtPolicyServiceBuildOldEntryRefs) so that other config referencing
+// an entry by path does not churn on every apply.
+func getServiceEntryIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "NSX ID of this service entry",
+		Optional:    true,
+		Computed:    true,
+	}
+}
+
 func resourceNsxtPolicyService() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNsxtPolicyServiceCreate,
@@ -38,8 +55,10 @@ func resourceNsxtPolicyService() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "ICMP type service entry",
 				Optional:    true,
+				Set:         hashIcmpEntry,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
 						"display_name": getOptionalDisplayNameSchema(),
 						"description":  getDescriptionSchema(),
 						"protocol": {
@@ -69,8 +88,10 @@ func resourceNsxtPolicyService() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "L4 port set type service entry",
 				Optional:    true,
+				Set:         hashL4Entry,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
 						"display_name": getOptionalDisplayNameSchema(),
 						"description":  getDescriptionSchema(),
 						"destination_ports": {
@@ -105,8 +126,10 @@ func resourceNsxtPolicyService() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "IGMP type service entry",
 				Optional:    true,
+				Set:         hashIgmpEntry,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
 						"display_name": getOptionalDisplayNameSchema(),
 						"description":  getDescriptionSchema(),
 					},
@@ -117,8 +140,10 @@ func resourceNsxtPolicyService() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "Ether type service entry",
 				Optional:    true,
+				Set:         hashEtherEntry,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
 						"display_name": getOptionalDisplayNameSchema(),
 						"description":  getDescriptionSchema(),
 						"ether_type": {
@@ -134,8 +159,10 @@ func resourceNsxtPolicyService() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "IP Protocol type service entry",
 				Optional:    true,
+				Set:         hashIPProtocolEntry,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
 						"display_name": getOptionalDisplayNameSchema(),
 						"description":  getDescriptionSchema(),
 						"protocol": {
@@ -152,8 +179,10 @@ func resourceNsxtPolicyService() *schema.Resource {
 				Type:        schema.TypeSet,
 				Description: "Algorithm type service entry",
 				Optional:    true,
+				Set:         hashAlgEntry,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
 						"display_name": getOptionalDisplayNameSchema(),
 						"description":  getDescriptionSchema(),
 						"destination_port": {
@@ -180,15 +209,209 @@ func resourceNsxtPolicyService() *schema.Resource {
 					},
 				},
 			},
+
+			"nested_service_entry": {
+				Type:        schema.TypeSet,
+				Description: "Nested service type service entry, referencing another policy service",
+				Optional:    true,
+				Set:         hashNestedEntry,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nsx_id":       getServiceEntryIDSchema(),
+						"display_name": getOptionalDisplayNameSchema(),
+						"description":  getDescriptionSchema(),
+						"service_path": {
+							Type:        schema.TypeString,
+							Description: "The path of the nested service this entry points to",
+							Required:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*data.StructValue, error) {
+// policyServiceEntryRef identifies a previously-created service entry that a
+// schema diff needs to reconcile: either by reusing its id for an entry that
+// is still present, or by marking it for deletion when it is not.
+type policyServiceEntryRef struct {
+	id           string
+	resourceType string
+}
+
+// serviceEntryKey builds a stable identity for a service entry out of the
+// fields that make it semantically unique (display name plus whatever
+// protocol/port/etc fields distinguish entries of that type). It is used to
+// recognize the "same" entry across applies even though configured entries
+// usually carry no id of their own, so unchanged entries keep their NSX id instead of being
+// re-created with a new one on every Update.
+func serviceEntryKey(parts ...string) string {
+	return strings.Join(parts, "|")
+}
+
+func sortedPortsKey(ports []string) string {
+	sorted := append([]string{}, ports...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func icmpEntryKey(entryData map[string]interface{}) string {
+	return serviceEntryKey("icmp", entryData["display_name"].(string), entryData["protocol"].(string), entryData["icmp_type"].(string), entryData["icmp_code"].(string))
+}
+
+func l4EntryKey(entryData map[string]interface{}) string {
+	destinationPorts := interface2StringList(entryData["destination_ports"].(*schema.Set).List())
+	sourcePorts := interface2StringList(entryData["source_ports"].(*schema.Set).List())
+	return serviceEntryKey("l4", entryData["display_name"].(string), entryData["protocol"].(string), sortedPortsKey(destinationPorts), sortedPortsKey(sourcePorts))
+}
+
+func igmpEntryKey(entryData map[string]interface{}) string {
+	return serviceEntryKey("igmp", entryData["display_name"].(string))
+}
+
+func etherEntryKey(entryData map[string]interface{}) string {
+	return serviceEntryKey("ether", entryData["display_name"].(string), strconv.Itoa(entryData["ether_type"].(int)))
+}
+
+func ipProtocolEntryKey(entryData map[string]interface{}) string {
+	return serviceEntryKey("ip_protocol", entryData["display_name"].(string), strconv.Itoa(entryData["protocol"].(int)))
+}
+
+func algEntryKey(entryData map[string]interface{}) string {
+	sourcePorts := interface2StringList(entryData["source_ports"].(*schema.Set).List())
+	return serviceEntryKey("algorithm", entryData["display_name"].(string), entryData["algorithm"].(string), entryData["destination_port"].(string), sortedPortsKey(sourcePorts))
+}
+
+func nestedEntryKey(entryData map[string]interface{}) string {
+	return serviceEntryKey("nested", entryData["display_name"].(string), entryData["service_path"].(string))
+}
+
+// hashIcmpEntry and the other hashXxxEntry functions below are the Set hash
+// funcs for their respective entry blocks. They reuse the same
+// serviceEntryKey used for stable-id matching, so that two entries NSX would
+// treat as "the same" also hash to the same set member and do not show up as
+// a spurious diff when the next Read returns them in a different order.
+func hashIcmpEntry(i interface{}) int {
+	return hashcode.String(icmpEntryKey(i.(map[string]interface{})))
+}
+
+func hashL4Entry(i interface{}) int {
+	return hashcode.String(l4EntryKey(i.(map[string]interface{})))
+}
+
+func hashIgmpEntry(i interface{}) int {
+	return hashcode.String(igmpEntryKey(i.(map[string]interface{})))
+}
+
+func hashEtherEntry(i interface{}) int {
+	return hashcode.String(etherEntryKey(i.(map[string]interface{})))
+}
+
+func hashIPProtocolEntry(i interface{}) int {
+	return hashcode.String(ipProtocolEntryKey(i.(map[string]interface{})))
+}
+
+func hashAlgEntry(i interface{}) int {
+	return hashcode.String(algEntryKey(i.(map[string]interface{})))
+}
+
+func hashNestedEntry(i interface{}) int {
+	return hashcode.String(nestedEntryKey(i.(map[string]interface{})))
+}
+
+// convertServiceEntry wraps the repeated ConvertToVapi/type-assert dance that
+// every service entry type needs.
+func convertServiceEntry(converter *bindings.TypeConverter, entry interface{}, bindingType bindings.BindingType) (*data.StructValue, error) {
+	dataValue, errs := converter.ConvertToVapi(entry, bindingType)
+	if errs != nil {
+		return nil, errs[0]
+	}
+	return dataValue.(*data.StructValue), nil
+}
+
+// resourceNsxtPolicyServiceBuildOldEntryRefs reads the previous state of each
+// entry block and indexes the entries NSX ids by serviceEntryKey, so that
+// resourceNsxtPolicyServiceGetEntriesFromSchema can hand out the same id to an
+// entry that did not actually change.
+func resourceNsxtPolicyServiceBuildOldEntryRefs(d *schema.ResourceData) map[string]policyServiceEntryRef {
+	refs := make(map[string]policyServiceEntryRef)
+
+	addRefs := func(attr string, resourceType string, keyFunc func(map[string]interface{}) string) {
+		old, _ := d.GetChange(attr)
+		for _, rawEntry := range old.(*schema.Set).List() {
+			entryData := rawEntry.(map[string]interface{})
+			id, _ := entryData["nsx_id"].(string)
+			if id == "" {
+				continue
+			}
+			refs[keyFunc(entryData)] = policyServiceEntryRef{id: id, resourceType: resourceType}
+		}
+	}
+
+	addRefs("icmp_entry", model.ServiceEntry_RESOURCE_TYPE_ICMPTYPESERVICEENTRY, icmpEntryKey)
+	addRefs("l4_port_set_entry", model.ServiceEntry_RESOURCE_TYPE_L4PORTSETSERVICEENTRY, l4EntryKey)
+	addRefs("igmp_entry", model.ServiceEntry_RESOURCE_TYPE_IGMPTYPESERVICEENTRY, igmpEntryKey)
+	addRefs("ether_type_entry", model.ServiceEntry_RESOURCE_TYPE_ETHERTYPESERVICEENTRY, etherEntryKey)
+	addRefs("ip_protocol_entry", model.ServiceEntry_RESOURCE_TYPE_IPPROTOCOLSERVICEENTRY, ipProtocolEntryKey)
+	addRefs("algorithm_entry", model.ServiceEntry_RESOURCE_TYPE_ALGTYPESERVICEENTRY, algEntryKey)
+	addRefs("nested_service_entry", model.ServiceEntry_RESOURCE_TYPE_NESTEDSERVICESERVICEENTRY, nestedEntryKey)
+
+	return refs
+}
+
+// serviceEntryDeleteStub builds the minimal payload NSX expects to delete a
+// service entry via PATCH: its id, resource type and MarkedForDelete set.
+func serviceEntryDeleteStub(converter *bindings.TypeConverter, ref policyServiceEntryRef) (*data.StructValue, error) {
+	id := ref.id
+	markedForDelete := true
+
+	switch ref.resourceType {
+	case model.ServiceEntry_RESOURCE_TYPE_ICMPTYPESERVICEENTRY:
+		entry := model.ICMPTypeServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.ICMPTypeServiceEntryBindingType())
+	case model.ServiceEntry_RESOURCE_TYPE_L4PORTSETSERVICEENTRY:
+		entry := model.L4PortSetServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.L4PortSetServiceEntryBindingType())
+	case model.ServiceEntry_RESOURCE_TYPE_IGMPTYPESERVICEENTRY:
+		entry := model.IGMPTypeServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.IGMPTypeServiceEntryBindingType())
+	case model.ServiceEntry_RESOURCE_TYPE_ETHERTYPESERVICEENTRY:
+		entry := model.EtherTypeServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.EtherTypeServiceEntryBindingType())
+	case model.ServiceEntry_RESOURCE_TYPE_IPPROTOCOLSERVICEENTRY:
+		entry := model.IPProtocolServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.IPProtocolServiceEntryBindingType())
+	case model.ServiceEntry_RESOURCE_TYPE_ALGTYPESERVICEENTRY:
+		entry := model.ALGTypeServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.ALGTypeServiceEntryBindingType())
+	case model.ServiceEntry_RESOURCE_TYPE_NESTEDSERVICESERVICEENTRY:
+		entry := model.NestedServiceServiceEntry{Id: &id, ResourceType: ref.resourceType, MarkedForDelete: &markedForDelete}
+		return convertServiceEntry(converter, entry, model.NestedServiceServiceEntryBindingType())
+	}
+
+	return nil, fmt.Errorf("Unknown service entry resource type %s", ref.resourceType)
+}
+
+// resourceNsxtPolicyServiceGetEntriesFromSchema converts the configured entry
+// blocks into NSX service entries. oldEntryIds, when provided, maps
+// serviceEntryKey -> the entry's existing NSX id; entries found there reuse
+// that id instead of getting a new one, and are removed from the map as they
+// are claimed. Callers that need to delete entries (Update) should treat
+// whatever is left in oldEntryIds afterwards as removed.
+func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData, oldEntryIds map[string]policyServiceEntryRef) ([]*data.StructValue, error) {
 	converter := bindings.NewTypeConverter()
 	converter.SetMode(bindings.REST)
 	serviceEntries := []*data.StructValue{}
 
+	resolveID := func(key string) string {
+		if ref, ok := oldEntryIds[key]; ok {
+			delete(oldEntryIds, key)
+			return ref.id
+		}
+		return newUUID()
+	}
+
 	// ICMP Type service entries
 	icmpEntries := d.Get("icmp_entry").(*schema.Set).List()
 	for _, icmpEntry := range icmpEntries {
@@ -216,8 +439,7 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 		displayName := entryData["display_name"].(string)
 		description := entryData["description"].(string)
 
-		// Use a different random Id each time
-		id := newUUID()
+		id := resolveID(icmpEntryKey(entryData))
 
 		serviceEntry := model.ICMPTypeServiceEntry{
 			Id:           &id,
@@ -228,12 +450,10 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 			Protocol:     protocol,
 			ResourceType: model.ServiceEntry_RESOURCE_TYPE_ICMPTYPESERVICEENTRY,
 		}
-		dataValue, errs := converter.ConvertToVapi(serviceEntry, model.ICMPTypeServiceEntryBindingType())
-		if errs != nil {
-			return serviceEntries, errs[0]
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.ICMPTypeServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
 		}
-		var entryStruct *data.StructValue
-		entryStruct = dataValue.(*data.StructValue)
 		serviceEntries = append(serviceEntries, entryStruct)
 	}
 
@@ -247,8 +467,7 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 		displayName := entryData["display_name"].(string)
 		description := entryData["description"].(string)
 
-		// Use a different random Id each time
-		id := newUUID()
+		id := resolveID(l4EntryKey(entryData))
 
 		serviceEntry := model.L4PortSetServiceEntry{
 			Id:               &id,
@@ -259,12 +478,10 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 			L4Protocol:       l4Protocol,
 			ResourceType:     model.ServiceEntry_RESOURCE_TYPE_L4PORTSETSERVICEENTRY,
 		}
-		dataValue, errs := converter.ConvertToVapi(serviceEntry, model.L4PortSetServiceEntryBindingType())
-		if errs != nil {
-			return serviceEntries, errs[0]
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.L4PortSetServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
 		}
-		var entryStruct *data.StructValue
-		entryStruct = dataValue.(*data.StructValue)
 		serviceEntries = append(serviceEntries, entryStruct)
 	}
 
@@ -275,8 +492,7 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 		displayName := entryData["display_name"].(string)
 		description := entryData["description"].(string)
 
-		// Use a different random Id each time
-		id := newUUID()
+		id := resolveID(igmpEntryKey(entryData))
 
 		serviceEntry := model.IGMPTypeServiceEntry{
 			Id:           &id,
@@ -284,12 +500,10 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 			Description:  &description,
 			ResourceType: model.ServiceEntry_RESOURCE_TYPE_IGMPTYPESERVICEENTRY,
 		}
-		dataValue, errs := converter.ConvertToVapi(serviceEntry, model.IGMPTypeServiceEntryBindingType())
-		if errs != nil {
-			return serviceEntries, errs[0]
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.IGMPTypeServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
 		}
-		var entryStruct *data.StructValue
-		entryStruct = dataValue.(*data.StructValue)
 		serviceEntries = append(serviceEntries, entryStruct)
 	}
 
@@ -301,8 +515,7 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 		description := entryData["description"].(string)
 		etherType := int64(entryData["ether_type"].(int))
 
-		// Use a different random Id each time
-		id := newUUID()
+		id := resolveID(etherEntryKey(entryData))
 
 		serviceEntry := model.EtherTypeServiceEntry{
 			Id:           &id,
@@ -311,12 +524,10 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 			EtherType:    etherType,
 			ResourceType: model.ServiceEntry_RESOURCE_TYPE_ETHERTYPESERVICEENTRY,
 		}
-		dataValue, errs := converter.ConvertToVapi(serviceEntry, model.EtherTypeServiceEntryBindingType())
-		if errs != nil {
-			return serviceEntries, errs[0]
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.EtherTypeServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
 		}
-		var entryStruct *data.StructValue
-		entryStruct = dataValue.(*data.StructValue)
 		serviceEntries = append(serviceEntries, entryStruct)
 	}
 
@@ -328,8 +539,7 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 		description := entryData["description"].(string)
 		protocolNumber := int64(entryData["protocol"].(int))
 
-		// Use a different random Id each time
-		id := newUUID()
+		id := resolveID(ipProtocolEntryKey(entryData))
 
 		serviceEntry := model.IPProtocolServiceEntry{
 			Id:             &id,
@@ -338,12 +548,10 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 			ProtocolNumber: protocolNumber,
 			ResourceType:   model.ServiceEntry_RESOURCE_TYPE_IPPROTOCOLSERVICEENTRY,
 		}
-		dataValue, errs := converter.ConvertToVapi(serviceEntry, model.IPProtocolServiceEntryBindingType())
-		if errs != nil {
-			return serviceEntries, errs[0]
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.IPProtocolServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
 		}
-		var entryStruct *data.StructValue
-		entryStruct = dataValue.(*data.StructValue)
 		serviceEntries = append(serviceEntries, entryStruct)
 	}
 
@@ -358,8 +566,7 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 		destinationPorts := make([]string, 0, 1)
 		destinationPorts = append(destinationPorts, entryData["destination_port"].(string))
 
-		// Use a different random Id each time
-		id := newUUID()
+		id := resolveID(algEntryKey(entryData))
 
 		serviceEntry := model.ALGTypeServiceEntry{
 			Id:               &id,
@@ -370,12 +577,34 @@ func resourceNsxtPolicyServiceGetEntriesFromSchema(d *schema.ResourceData) ([]*d
 			SourcePorts:      sourcePorts,
 			ResourceType:     model.ServiceEntry_RESOURCE_TYPE_ALGTYPESERVICEENTRY,
 		}
-		dataValue, errs := converter.ConvertToVapi(serviceEntry, model.ALGTypeServiceEntryBindingType())
-		if errs != nil {
-			return serviceEntries, errs[0]
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.ALGTypeServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
+		}
+		serviceEntries = append(serviceEntries, entryStruct)
+	}
+
+	// Nested Type service entries
+	nestedEntries := d.Get("nested_service_entry").(*schema.Set).List()
+	for _, nestedEntry := range nestedEntries {
+		entryData := nestedEntry.(map[string]interface{})
+		displayName := entryData["display_name"].(string)
+		description := entryData["description"].(string)
+		servicePath := entryData["service_path"].(string)
+
+		id := resolveID(nestedEntryKey(entryData))
+
+		serviceEntry := model.NestedServiceServiceEntry{
+			Id:                &id,
+			DisplayName:       &displayName,
+			Description:       &description,
+			NestedServicePath: &servicePath,
+			ResourceType:      model.ServiceEntry_RESOURCE_TYPE_NESTEDSERVICESERVICEENTRY,
+		}
+		entryStruct, err := convertServiceEntry(converter, serviceEntry, model.NestedServiceServiceEntryBindingType())
+		if err != nil {
+			return serviceEntries, err
 		}
-		var entryStruct *data.StructValue
-		entryStruct = dataValue.(*data.StructValue)
 		serviceEntries = append(serviceEntries, entryStruct)
 	}
 
@@ -418,7 +647,7 @@ func resourceNsxtPolicyServiceCreate(d *schema.ResourceData, m interface{}) erro
 	displayName := d.Get("display_name").(string)
 	description := d.Get("description").(string)
 	tags := getPolicyTagsFromSchema(d)
-	serviceEntries, errc := resourceNsxtPolicyServiceGetEntriesFromSchema(d)
+	serviceEntries, errc := resourceNsxtPolicyServiceGetEntriesFromSchema(d, nil)
 	if errc != nil {
 		return fmt.Errorf("Error during Service entries conversion: %v", errc)
 	}
@@ -463,7 +692,129 @@ func resourceNsxtPolicyServiceRead(d *schema.ResourceData, m interface{}) error
 	d.Set("path", obj.Path)
 	d.Set("revision", obj.Revision)
 
-	// Translate the returned service entries
+	return setPolicyServiceEntriesInSchema(d, obj.ServiceEntries)
+}
+
+// decodeICMPTypeServiceEntry, and the sibling decodeXxxServiceEntry helpers
+// below, each attempt to decode a single raw service entry as their type.
+// They return ok=false (and a nil elem) when entry is some other type, so
+// setPolicyServiceEntriesInSchema can just try each in turn.
+
+func decodeICMPTypeServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.ICMPTypeServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.ICMPTypeServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	if serviceEntry.IcmpType != nil {
+		elem["icmp_type"] = strconv.Itoa(int(*serviceEntry.IcmpType))
+	} else {
+		elem["icmp_type"] = ""
+	}
+	if serviceEntry.IcmpCode != nil {
+		elem["icmp_code"] = strconv.Itoa(int(*serviceEntry.IcmpCode))
+	} else {
+		elem["icmp_code"] = ""
+	}
+	elem["protocol"] = serviceEntry.Protocol
+	return elem, *serviceEntry.Id, true
+}
+
+func decodeL4PortSetServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.L4PortSetServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.L4PortSetServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	elem["destination_ports"] = serviceEntry.DestinationPorts
+	elem["source_ports"] = serviceEntry.SourcePorts
+	elem["protocol"] = serviceEntry.L4Protocol
+	return elem, *serviceEntry.Id, true
+}
+
+func decodeEtherTypeServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.EtherTypeServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.EtherTypeServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	elem["ether_type"] = serviceEntry.EtherType
+	return elem, *serviceEntry.Id, true
+}
+
+func decodeIPProtocolServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.IPProtocolServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.IPProtocolServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	elem["protocol"] = serviceEntry.ProtocolNumber
+	return elem, *serviceEntry.Id, true
+}
+
+func decodeALGTypeServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.ALGTypeServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.ALGTypeServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	elem["algorithm"] = serviceEntry.Alg
+	elem["destination_port"] = serviceEntry.DestinationPorts[0]
+	elem["source_ports"] = serviceEntry.SourcePorts
+	return elem, *serviceEntry.Id, true
+}
+
+func decodeIGMPTypeServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.IGMPTypeServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.IGMPTypeServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	return elem, *serviceEntry.Id, true
+}
+
+func decodeNestedServiceServiceEntry(converter *bindings.TypeConverter, entry *data.StructValue) (map[string]interface{}, string, bool) {
+	decoded, errs := converter.ConvertToGolang(entry, model.NestedServiceServiceEntryBindingType())
+	if errs != nil {
+		return nil, "", false
+	}
+	serviceEntry := decoded.(model.NestedServiceServiceEntry)
+	elem := make(map[string]interface{})
+	elem["nsx_id"] = *serviceEntry.Id
+	elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
+	elem["description"] = serviceEntry.Description
+	elem["service_path"] = serviceEntry.NestedServicePath
+	return elem, *serviceEntry.Id, true
+}
+
+// setPolicyServiceEntriesInSchema decodes the service entries returned by NSX into
+// their per-type schema blocks and sets them on d. Shared by the nsxt_policy_service
+// resource and the nsxt_policy_service data source.
+func setPolicyServiceEntriesInSchema(d *schema.ResourceData, serviceEntries []*data.StructValue) error {
 	converter := bindings.NewTypeConverter()
 	converter.SetMode(bindings.REST)
 	var icmpEntriesList []map[string]interface{}
@@ -472,81 +823,29 @@ func resourceNsxtPolicyServiceRead(d *schema.ResourceData, m interface{}) error
 	var etherEntriesList []map[string]interface{}
 	var ipProtEntriesList []map[string]interface{}
 	var algEntriesList []map[string]interface{}
+	var nestedEntriesList []map[string]interface{}
 
-	for _, entry := range obj.ServiceEntries {
-		elem := make(map[string]interface{})
-		icmpEntry, errs := converter.ConvertToGolang(entry, model.ICMPTypeServiceEntryBindingType())
-		if errs == nil {
-			serviceEntry := icmpEntry.(model.ICMPTypeServiceEntry)
-			elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
-			elem["description"] = serviceEntry.Description
-			if serviceEntry.IcmpType != nil {
-				elem["icmp_type"] = strconv.Itoa(int(*serviceEntry.IcmpType))
-			} else {
-				elem["icmp_type"] = ""
-			}
-			if serviceEntry.IcmpCode != nil {
-				elem["icmp_code"] = strconv.Itoa(int(*serviceEntry.IcmpCode))
-			} else {
-				elem["icmp_code"] = ""
-			}
-			elem["protocol"] = serviceEntry.Protocol
+	for _, entry := range serviceEntries {
+		if elem, _, ok := decodeICMPTypeServiceEntry(converter, entry); ok {
 			icmpEntriesList = append(icmpEntriesList, elem)
+		} else if elem, _, ok := decodeL4PortSetServiceEntry(converter, entry); ok {
+			l4EntriesList = append(l4EntriesList, elem)
+		} else if elem, _, ok := decodeEtherTypeServiceEntry(converter, entry); ok {
+			etherEntriesList = append(etherEntriesList, elem)
+		} else if elem, _, ok := decodeIPProtocolServiceEntry(converter, entry); ok {
+			ipProtEntriesList = append(ipProtEntriesList, elem)
+		} else if elem, _, ok := decodeALGTypeServiceEntry(converter, entry); ok {
+			algEntriesList = append(algEntriesList, elem)
+		} else if elem, _, ok := decodeIGMPTypeServiceEntry(converter, entry); ok {
+			igmpEntriesList = append(igmpEntriesList, elem)
+		} else if elem, _, ok := decodeNestedServiceServiceEntry(converter, entry); ok {
+			nestedEntriesList = append(nestedEntriesList, elem)
 		} else {
-			l4Entry, l4Errs := converter.ConvertToGolang(entry, model.L4PortSetServiceEntryBindingType())
-			if l4Errs == nil {
-				serviceEntry := l4Entry.(model.L4PortSetServiceEntry)
-				elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
-				elem["description"] = serviceEntry.Description
-				elem["destination_ports"] = serviceEntry.DestinationPorts
-				elem["source_ports"] = serviceEntry.SourcePorts
-				elem["protocol"] = serviceEntry.L4Protocol
-				l4EntriesList = append(l4EntriesList, elem)
-			} else {
-				etherEntry, etherErrs := converter.ConvertToGolang(entry, model.EtherTypeServiceEntryBindingType())
-				if etherErrs == nil {
-					serviceEntry := etherEntry.(model.EtherTypeServiceEntry)
-					elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
-					elem["description"] = serviceEntry.Description
-					elem["ether_type"] = serviceEntry.EtherType
-					etherEntriesList = append(etherEntriesList, elem)
-				} else {
-					ipProtEntry, ipProtErrs := converter.ConvertToGolang(entry, model.IPProtocolServiceEntryBindingType())
-					if ipProtErrs == nil {
-						serviceEntry := ipProtEntry.(model.IPProtocolServiceEntry)
-						elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
-						elem["description"] = serviceEntry.Description
-						elem["protocol"] = serviceEntry.ProtocolNumber
-						ipProtEntriesList = append(ipProtEntriesList, elem)
-					} else {
-						algEntry, algErrs := converter.ConvertToGolang(entry, model.ALGTypeServiceEntryBindingType())
-						if algErrs == nil {
-							serviceEntry := algEntry.(model.ALGTypeServiceEntry)
-							elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
-							elem["description"] = serviceEntry.Description
-							elem["algorithm"] = serviceEntry.Alg
-							elem["destination_port"] = serviceEntry.DestinationPorts[0]
-							elem["source_ports"] = serviceEntry.SourcePorts
-							algEntriesList = append(algEntriesList, elem)
-						} else {
-							igmpEntry, igmpErrs := converter.ConvertToGolang(entry, model.IGMPTypeServiceEntryBindingType())
-							if igmpErrs == nil {
-								serviceEntry := igmpEntry.(model.IGMPTypeServiceEntry)
-								elem["display_name"] = filterServiceEntryDisplayName(*serviceEntry.DisplayName, *serviceEntry.Id)
-								elem["description"] = serviceEntry.Description
-								igmpEntriesList = append(igmpEntriesList, elem)
-							} else {
-								// Unknown service entry type
-								return igmpErrs[0]
-							}
-						}
-					}
-				}
-			}
+			return fmt.Errorf("Unknown service entry type encountered")
 		}
 	}
 
-	err = d.Set("icmp_entry", icmpEntriesList)
+	err := d.Set("icmp_entry", icmpEntriesList)
 	if err != nil {
 		return err
 	}
@@ -576,6 +875,11 @@ func resourceNsxtPolicyServiceRead(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
+	err = d.Set("nested_service_entry", nestedEntriesList)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -593,10 +897,27 @@ func resourceNsxtPolicyServiceUpdate(d *schema.ResourceData, m interface{}) erro
 	description := d.Get("description").(string)
 	revision := int64(d.Get("revision").(int))
 	tags := getPolicyTagsFromSchema(d)
-	serviceEntries, errc := resourceNsxtPolicyServiceGetEntriesFromSchema(d)
+
+	// Entries that are unchanged keep the NSX id they already have; entries
+	// left unclaimed in oldEntryRefs after the conversion below were removed
+	// from config and need to be explicitly marked for deletion, since PATCH
+	// merges entries by id instead of replacing the whole list.
+	oldEntryRefs := resourceNsxtPolicyServiceBuildOldEntryRefs(d)
+	serviceEntries, errc := resourceNsxtPolicyServiceGetEntriesFromSchema(d, oldEntryRefs)
 	if errc != nil {
 		return fmt.Errorf("Error during Service entries conversion: %v", errc)
 	}
+
+	converter := bindings.NewTypeConverter()
+	converter.SetMode(bindings.REST)
+	for _, ref := range oldEntryRefs {
+		deleteStub, err := serviceEntryDeleteStub(converter, ref)
+		if err != nil {
+			return err
+		}
+		serviceEntries = append(serviceEntries, deleteStub)
+	}
+
 	obj := model.Service{
 		DisplayName:    &displayName,
 		Description:    &description,
@@ -605,8 +926,9 @@ func resourceNsxtPolicyServiceUpdate(d *schema.ResourceData, m interface{}) erro
 		Revision:       &revision,
 	}
 
-	// Update the resource using Update to totally replace the list of entries
-	_, err := client.Update(id, obj)
+	// Update the resource using PATCH, so entries are merged by id rather
+	// than the whole list being replaced
+	err := client.Patch(id, obj)
 	if err != nil {
 		return handleUpdateError("Service", id, err)
 	}
@@ -621,10 +943,11 @@ func resourceNsxtPolicyServiceDelete(d *schema.ResourceData, m interface{}) erro
 
 	connector := getPolicyConnector(m)
 	client := infra.NewDefaultServicesClient(connector)
+
 	err := client.Delete(id)
 	if err != nil {
 		err = handleDeleteError("Service", id, err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,61 @@
+/* Copyright © 2019 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestSortedPortsKey(t *testing.T) {
+	a := sortedPortsKey([]string{"80", "22", "443"})
+	b := sortedPortsKey([]string{"443", "80", "22"})
+	if a != b {
+		t.Fatalf("expected order-independent keys to match, got %q and %q", a, b)
+	}
+
+	if sortedPortsKey([]string{"80"}) == sortedPortsKey([]string{"443"}) {
+		t.Fatalf("expected different port sets to produce different keys")
+	}
+}
+
+func TestResourceNsxtPolicyServiceGetEntriesFromSchemaReusesID(t *testing.T) {
+	res := resourceNsxtPolicyService()
+
+	raw := map[string]interface{}{
+		"igmp_entry": []interface{}{
+			map[string]interface{}{
+				"nsx_id":       "",
+				"display_name": "unchanged",
+				"description":  "",
+			},
+			map[string]interface{}{
+				"nsx_id":       "",
+				"display_name": "new",
+				"description":  "",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, res.Schema, raw)
+
+	oldRefs := map[string]policyServiceEntryRef{
+		igmpEntryKey(map[string]interface{}{"display_name": "unchanged"}): {
+			id:           "existing-id",
+			resourceType: "IGMPTypeServiceEntry",
+		},
+	}
+
+	entries, err := resourceNsxtPolicyServiceGetEntriesFromSchema(d, oldRefs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	if len(oldRefs) != 0 {
+		t.Fatalf("expected the matched entry to be claimed from oldRefs, got %d left over", len(oldRefs))
+	}
+}